@@ -0,0 +1,15 @@
+package errorwrapper
+
+import "testing"
+
+func TestBuilderMethodsCompose(t *testing.T) {
+	w := New('.', "A").WithStackTrace().WithFormatter(LogfmtFormatter{}).(*errWrapper)
+
+	err := w.NewErrorString("boom").(*errWrapper)
+	if len(err.StackTrace()) == 0 {
+		t.Fatalf("StackTrace() is empty, want WithStackTrace to have captured frames")
+	}
+	if _, ok := err.formatter.(LogfmtFormatter); !ok {
+		t.Fatalf("formatter = %T, want LogfmtFormatter (WithFormatter should carry over)", err.formatter)
+	}
+}
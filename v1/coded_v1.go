@@ -0,0 +1,101 @@
+package errorwrapper
+
+import (
+	"github.com/Colduction/errorwrapper/v1/codes"
+)
+
+// NewCodedError creates a new error tagged with base's codespace and code,
+// using base's description as the underlying cause. errors.Is(err, base)
+// returns true for the result, and for any further error that wraps it.
+func (ew errWrapper) NewCodedError(base *codes.CodedError, msg ...string) error {
+	var tmpMsg string
+	if len(msg) >= 1 {
+		tmpMsg = msg[0]
+	}
+	newErr := &errWrapper{
+		prefix:    ew.prefix,
+		err:       base,
+		msg:       tmpMsg,
+		errJoiner: ew.errJoiner,
+		code:      base,
+		formatter: ew.formatter,
+	}
+	if ew.withStack {
+		newErr.pcs = captureStack()
+	}
+	return newErr
+}
+
+// Wrap wraps err with the wrapper's own prefix and a new message while
+// tagging the result with base's codespace and code, so errors.Is(err,
+// base), Code and Codespace keep working across further wrapping. Like
+// NewError, only this wrapper's own prefix is stored; the full, combined
+// prefix is computed on demand by walking the chain.
+func (ew errWrapper) Wrap(base *codes.CodedError, err error, msg ...string) error {
+	if err == nil {
+		return nil
+	}
+	var tmpMsg string
+	if len(msg) >= 1 {
+		tmpMsg = msg[0]
+	}
+	newErr := &errWrapper{
+		prefix:    ew.prefix,
+		err:       err,
+		msg:       tmpMsg,
+		errJoiner: ew.errJoiner,
+		code:      base,
+		formatter: ew.formatter,
+	}
+	if ew.withStack {
+		newErr.pcs = captureStack()
+	}
+	return newErr
+}
+
+// Code walks err's chain and returns the code of the nearest ancestor
+// tagged via NewCodedError or Wrap, or 0 if none is tagged.
+func Code(err error) uint32 {
+	_, code, ok := findCode(err)
+	if !ok {
+		return 0
+	}
+	return code
+}
+
+// Codespace walks err's chain and returns the codespace of the nearest
+// ancestor tagged via NewCodedError or Wrap, or "" if none is tagged.
+func Codespace(err error) string {
+	codespace, _, ok := findCode(err)
+	if !ok {
+		return ""
+	}
+	return codespace
+}
+
+// findCode walks err's chain in pre-order, depth-first order looking for
+// the nearest tagged ancestor, which may be an errWrapper carrying a code,
+// a bare *codes.CodedError, or (recursing into every branch) a *joinError.
+func findCode(err error) (string, uint32, bool) {
+	if err == nil {
+		return "", 0, false
+	}
+	if ew, ok := err.(*errWrapper); ok {
+		if ew.code != nil {
+			return ew.code.Codespace, ew.code.Code, true
+		}
+		return findCode(ew.err)
+	}
+	if ce, ok := err.(*codes.CodedError); ok {
+		return ce.Codespace, ce.Code, true
+	}
+	if je, ok := err.(*joinError); ok {
+		for _, child := range je.errs {
+			if codespace, code, found := findCode(child); found {
+				return codespace, code, true
+			}
+		}
+		return "", 0, false
+	}
+	return "", 0, false
+}
@@ -0,0 +1,22 @@
+package errorwrapper
+
+import (
+	"testing"
+
+	"github.com/Colduction/errorwrapper/v1/codes"
+)
+
+func TestCodeFindsNearestAncestorThroughJoin(t *testing.T) {
+	base := codes.Register("errorwrapper_test.coded", 1001, "not found")
+	w := New('.', "A")
+
+	coded := w.NewCodedError(base, "lookup failed")
+	joined := w.Join(coded, w.NewErrorString("boom"))
+
+	if got := Code(joined); got != base.Code {
+		t.Fatalf("Code(joined) = %d, want %d", got, base.Code)
+	}
+	if got := Codespace(joined); got != base.Codespace {
+		t.Fatalf("Codespace(joined) = %q, want %q", got, base.Codespace)
+	}
+}
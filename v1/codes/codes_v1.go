@@ -0,0 +1,51 @@
+// Package codes provides a codespace-scoped registry of structured error
+// codes, modeled after the cosmos-sdk errors package, for use with
+// errorwrapper's NewCodedError and Wrap constructors.
+package codes
+
+import (
+	"fmt"
+	"sync"
+)
+
+// CodedError is a registered, machine-readable error identity comprising a
+// codespace and a numeric code alongside a human-readable description.
+type CodedError struct {
+	Codespace   string
+	Code        uint32
+	Description string
+}
+
+// Error implements the error interface for CodedError.
+func (c *CodedError) Error() string {
+	return c.Description
+}
+
+var (
+	registryMu sync.Mutex
+	registry   = make(map[string]map[uint32]*CodedError)
+)
+
+// Register creates and records a new CodedError under codespace and code.
+// It panics if that (codespace, code) pair is already registered, since
+// codes are meant to be stable, compile-time identifiers declared once at
+// package init time.
+func Register(codespace string, code uint32, description string) *CodedError {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	codespaceRegistry, ok := registry[codespace]
+	if !ok {
+		codespaceRegistry = make(map[uint32]*CodedError)
+		registry[codespace] = codespaceRegistry
+	}
+	if _, ok := codespaceRegistry[code]; ok {
+		panic(fmt.Sprintf("errorwrapper/codes: code %d is already registered for codespace %q", code, codespace))
+	}
+	ce := &CodedError{
+		Codespace:   codespace,
+		Code:        code,
+		Description: description,
+	}
+	codespaceRegistry[code] = ce
+	return ce
+}
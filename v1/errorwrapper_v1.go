@@ -1,6 +1,10 @@
 package errorwrapper
 
-import "strings"
+import (
+	"strings"
+
+	"github.com/Colduction/errorwrapper/v1/codes"
+)
 
 const (
 	defaultErrJoiner byte   = 0x2E // Default character to join prefixes, which is '.'
@@ -23,6 +27,21 @@ type ErrorWrapper interface {
 	NewError(err error, msg ...string) error
 	// NewErrorString creates a new error from a string and wraps it with a message.
 	NewErrorString(errStr string, msg ...string) error
+	// Join combines multiple errors into one, applying the wrapper's prefix to
+	// each joined error that doesn't already carry its own.
+	Join(errs ...error) error
+	// NewCodedError creates a new error tagged with base's codespace and code.
+	NewCodedError(base *codes.CodedError, msg ...string) error
+	// Wrap wraps err while tagging it with base's codespace and code.
+	Wrap(base *codes.CodedError, err error, msg ...string) error
+	// WithStackTrace returns a copy of the wrapper that captures the call
+	// stack on every error created by NewError, NewErrorString,
+	// NewCodedError or Wrap; errors built by Join carry no stack. Use
+	// StackTrace on the resulting errors to retrieve it, or "%+v" to print it.
+	WithStackTrace() ErrorWrapper
+	// WithFormatter returns a copy of the wrapper that renders its errors'
+	// Error() output through formatter instead of DefaultFormatter.
+	WithFormatter(formatter Formatter) ErrorWrapper
 }
 
 // errWrapper is the concrete implementation of the ErrorWrapper interface.
@@ -31,14 +50,20 @@ type errWrapper struct {
 	msg       string
 	prefix    string
 	errJoiner byte
+	withStack bool
+	pcs       []uintptr
+	code      *codes.CodedError
+	formatter Formatter
 }
 
 // Statically assert that *errWrapper implements the ErrorWrapper interface.
 // This line will cause a compile-time error if the interface is not satisfied.
 var _ ErrorWrapper = (*errWrapper)(nil)
 
-// New creates and returns a new ErrorWrapper.
-// It accepts an optional joiner byte for prefixes and an optional initial prefix string.
+// New creates and returns a new ErrorWrapper. It accepts a joiner byte for
+// prefixes (0 selects the default, '.') and an optional prefix. Chain
+// WithStackTrace and/or WithFormatter onto the result to compose further
+// behavior, e.g. New('.', "svc").WithStackTrace().WithFormatter(JSONFormatter{}).
 func New(errJoiner byte, prefix ...string) ErrorWrapper {
 	ew := &errWrapper{
 		errJoiner: errJoiner,
@@ -52,24 +77,64 @@ func New(errJoiner byte, prefix ...string) ErrorWrapper {
 	return ew
 }
 
-// unwrapRecursively traverses a chain of errWrapper errors.
-// It returns the combined prefix of all wrappers and the root, non-wrapper error.
+// WithStackTrace returns a copy of ew that captures the call stack on every
+// error created by NewError, NewErrorString, NewCodedError or Wrap. Errors
+// built by Join, and the prefix wrappers Join adds, carry no stack.
+func (ew errWrapper) WithStackTrace() ErrorWrapper {
+	ew.withStack = true
+	return &ew
+}
+
+// WithFormatter returns a copy of ew that renders its errors' Error() output
+// through formatter instead of DefaultFormatter.
+func (ew errWrapper) WithFormatter(formatter Formatter) ErrorWrapper {
+	ew.formatter = formatter
+	return &ew
+}
+
+// unwrapRecursively traverses a chain of errWrapper (and, for joinError, its
+// joined branches) errors, starting at and including err itself. It returns
+// the combined prefix of every wrapper encountered (each contributing only
+// its own, locally-stored prefix segment) and the innermost error to report
+// as the cause. Error() and Is() call this at render/compare time rather
+// than having each wrapper's prefix pre-combined at construction time, so a
+// four-level NewError chain reports its prefixes once each, not repeated.
 func unwrapRecursively(err error, joiner byte) (string, error) {
-	if ew, ok := err.(*errWrapper); ok {
-		recursivePrefix, underlyingErr := unwrapRecursively(ew.err, joiner)
+	switch e := err.(type) {
+	case *errWrapper:
+		recursivePrefix, underlyingErr := unwrapRecursively(e.err, joiner)
 		var sb strings.Builder
-		sb.WriteString(ew.prefix)
-		if ew.prefix != "" && recursivePrefix != "" {
+		sb.WriteString(e.prefix)
+		if e.prefix != "" && recursivePrefix != "" {
 			sb.WriteByte(joiner)
 		}
 		sb.WriteString(recursivePrefix)
 		return sb.String(), underlyingErr
+	case *joinError:
+		var sb strings.Builder
+		for _, child := range e.errs {
+			childPrefix, _ := unwrapRecursively(child, joiner)
+			if childPrefix == "" {
+				continue
+			}
+			if sb.Len() > 0 {
+				sb.WriteByte(joiner)
+			}
+			sb.WriteString(childPrefix)
+		}
+		return sb.String(), e
+	default:
+		return "", err
 	}
-	return "", err
 }
 
-// NewError wraps an existing error with the wrapper's prefix and a new message.
-// If the error being wrapped is also an errWrapper, it combines their prefixes.
+// NewError wraps an existing error with the wrapper's own prefix and a new
+// message. The new errWrapper stores only this wrapper's own prefix, not a
+// copy combined with err's; the full, combined prefix is computed on demand
+// by walking the chain (see unwrapRecursively), so each level's prefix is
+// never baked into its ancestors' stored state. The original err is kept
+// intact (not flattened to its root cause) so that errors.Is and errors.As
+// can still traverse every intermediate wrapper.
 func (ew errWrapper) NewError(err error, msg ...string) error {
 	if err == nil {
 		return nil
@@ -78,21 +143,17 @@ func (ew errWrapper) NewError(err error, msg ...string) error {
 	if len(msg) >= 1 {
 		tmpMsg = msg[0]
 	}
-	var (
-		unwPrefix, undErr = unwrapRecursively(err, ew.errJoiner)
-		sb                strings.Builder
-	)
-	sb.WriteString(ew.prefix)
-	if ew.prefix != "" && unwPrefix != "" {
-		sb.WriteByte(ew.errJoiner)
-	}
-	sb.WriteString(unwPrefix)
-	return &errWrapper{
-		prefix:    sb.String(),
-		err:       undErr,
+	newErr := &errWrapper{
+		prefix:    ew.prefix,
+		err:       err,
 		msg:       tmpMsg,
 		errJoiner: ew.errJoiner,
+		formatter: ew.formatter,
 	}
+	if ew.withStack {
+		newErr.pcs = captureStack()
+	}
+	return newErr
 }
 
 // NewErrorString wraps a new error, created from a string, with the wrapper's prefix and a message.
@@ -104,38 +165,62 @@ func (ew errWrapper) NewErrorString(errStr string, msg ...string) error {
 	if len(msg) >= 1 {
 		tmpMsg = msg[0]
 	}
-	return &errWrapper{
-		prefix: ew.prefix,
-		err:    &errorString{errStr},
-		msg:    tmpMsg,
+	newErr := &errWrapper{
+		prefix:    ew.prefix,
+		err:       &errorString{errStr},
+		msg:       tmpMsg,
+		errJoiner: ew.errJoiner,
+		formatter: ew.formatter,
 	}
+	if ew.withStack {
+		newErr.pcs = captureStack()
+	}
+	return newErr
 }
 
-// Error implements the error interface for errWrapper, formatting the output string.
+// Error implements the error interface for errWrapper, formatting the output
+// string through the wrapper's Formatter (DefaultFormatter unless one was
+// set via WithFormatter). The combined prefix is computed here, by walking
+// ew and every wrapper it wraps, rather than read off a single pre-merged
+// field, since each level only stores its own prefix segment. msg,
+// codespace, and code are passed through raw; it is the Formatter's job to
+// decide how to lay them out.
 func (ew errWrapper) Error() string {
-	var (
-		sb          strings.Builder
-		isMsgFilled bool = ew.msg != ""
-	)
-	if ew.prefix != "" {
-		sb.WriteString(ew.prefix)
-		sb.WriteString(defaultMsgJoiner)
+	combinedPrefix, rootErr := unwrapRecursively(&ew, ew.errJoiner)
+	var codespace string
+	var code uint32
+	if ew.code != nil {
+		codespace = ew.code.Codespace
+		code = ew.code.Code
 	}
-	if isMsgFilled {
-		sb.WriteByte(0x5B)
-		sb.WriteString(ew.msg)
-		sb.WriteByte(0x5D)
+	formatter := ew.formatter
+	if formatter == nil {
+		formatter = DefaultFormatter{}
 	}
-	if ew.err != nil {
-		if isMsgFilled {
-			sb.WriteByte(0x20)
-		}
-		sb.WriteString(ew.err.Error())
-	}
-	return sb.String()
+	return formatter.Format(combinedPrefix, ew.msg, codespace, code, rootErr)
 }
 
-// Unwrap returns the underlying wrapped error, allowing for error chain inspection.
+// Unwrap returns the immediately wrapped error, preserving the full chain so
+// errors.Is and errors.As can inspect every intermediate errWrapper.
 func (ew *errWrapper) Unwrap() error {
 	return ew.err
 }
+
+// Is reports whether target is a sentinel error created with Register whose
+// prefix and message match this wrapper's. The prefix compared is ew's full,
+// combined prefix (ew's own plus every ancestor's), computed the same way as
+// Error() does, since sentinels are registered against the full path.
+// errors.Is calls this for every link in the chain, so a sentinel registered
+// for an intermediate wrapper is still matched even though Unwrap no longer
+// collapses the chain.
+func (ew *errWrapper) Is(target error) bool {
+	switch t := target.(type) {
+	case *sentinelError:
+		combinedPrefix, _ := unwrapRecursively(ew, ew.errJoiner)
+		return combinedPrefix == t.prefix && ew.msg == t.msg
+	case *codes.CodedError:
+		return ew.code != nil && ew.code == t
+	default:
+		return false
+	}
+}
@@ -0,0 +1,116 @@
+package errorwrapper
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+)
+
+// Formatter renders an errWrapper's combined prefix, its own raw message,
+// its code/codespace (the zero value, "" and 0, when the error wasn't
+// created via NewCodedError/Wrap), and its root cause into the final
+// Error() string. Set one via WithFormatter to change how errors render
+// without editing this package.
+type Formatter interface {
+	Format(prefix, msg, codespace string, code uint32, cause error) string
+}
+
+// DefaultFormatter reproduces errWrapper's historical layout:
+// "prefix: [code=1001] [msg] cause".
+type DefaultFormatter struct{}
+
+// Format implements Formatter for DefaultFormatter.
+func (DefaultFormatter) Format(prefix, msg, codespace string, code uint32, cause error) string {
+	var sb strings.Builder
+	if prefix != "" {
+		sb.WriteString(prefix)
+		sb.WriteString(defaultMsgJoiner)
+	}
+	wroteBracket := false
+	if codespace != "" || code != 0 {
+		sb.WriteString("[code=")
+		sb.WriteString(strconv.FormatUint(uint64(code), 10))
+		sb.WriteByte(']')
+		wroteBracket = true
+	}
+	if msg != "" {
+		if wroteBracket {
+			sb.WriteByte(0x20)
+		}
+		sb.WriteByte('[')
+		sb.WriteString(msg)
+		sb.WriteByte(']')
+		wroteBracket = true
+	}
+	if cause != nil {
+		if wroteBracket {
+			sb.WriteByte(0x20)
+		}
+		sb.WriteString(cause.Error())
+	}
+	return sb.String()
+}
+
+// LogfmtFormatter renders "prefix=... msg=... codespace=... code=... cause=..."
+// key/value pairs, suitable for logfmt-based structured logging pipelines.
+type LogfmtFormatter struct{}
+
+// Format implements Formatter for LogfmtFormatter.
+func (LogfmtFormatter) Format(prefix, msg, codespace string, code uint32, cause error) string {
+	var sb strings.Builder
+	writeField := func(key, value string) {
+		if value == "" {
+			return
+		}
+		if sb.Len() > 0 {
+			sb.WriteByte(0x20)
+		}
+		sb.WriteString(key)
+		sb.WriteString(`="`)
+		sb.WriteString(value)
+		sb.WriteByte('"')
+	}
+	writeField("prefix", prefix)
+	writeField("msg", msg)
+	writeField("codespace", codespace)
+	if code != 0 {
+		if sb.Len() > 0 {
+			sb.WriteByte(0x20)
+		}
+		sb.WriteString("code=")
+		sb.WriteString(strconv.FormatUint(uint64(code), 10))
+	}
+	if cause != nil {
+		writeField("cause", cause.Error())
+	}
+	return sb.String()
+}
+
+// JSONFormatter renders a single-line JSON object with prefix, msg,
+// codespace, code, and cause fields, suitable for JSON-based structured
+// logging pipelines.
+type JSONFormatter struct{}
+
+// Format implements Formatter for JSONFormatter.
+func (JSONFormatter) Format(prefix, msg, codespace string, code uint32, cause error) string {
+	obj := struct {
+		Prefix    string `json:"prefix,omitempty"`
+		Msg       string `json:"msg,omitempty"`
+		Codespace string `json:"codespace,omitempty"`
+		Code      uint32 `json:"code,omitempty"`
+		Cause     string `json:"cause,omitempty"`
+	}{
+		Prefix:    prefix,
+		Msg:       msg,
+		Codespace: codespace,
+		Code:      code,
+	}
+	if cause != nil {
+		obj.Cause = cause.Error()
+	}
+	b, err := json.Marshal(obj)
+	if err != nil {
+		return ""
+	}
+	return string(b)
+}
@@ -0,0 +1,73 @@
+package errorwrapper
+
+import "strings"
+
+// joinError aggregates multiple errors into one, mirroring the standard
+// library's errors.Join. Each joined error is responsible for rendering its
+// own prefix (see withPrefix); joinError itself only lays them out on
+// separate lines.
+type joinError struct {
+	errs []error
+}
+
+// Error implements the error interface for joinError, rendering each joined
+// error on its own line.
+func (je *joinError) Error() string {
+	var sb strings.Builder
+	for i, err := range je.errs {
+		if i > 0 {
+			sb.WriteByte(0x0A)
+		}
+		sb.WriteString(err.Error())
+	}
+	return sb.String()
+}
+
+// Unwrap returns every joined error, letting errors.Is and errors.As perform
+// a pre-order depth-first traversal across the whole tree.
+func (je *joinError) Unwrap() []error {
+	return je.errs
+}
+
+// withPrefix applies ew's prefix to err for use as a Join line, unless err
+// already carries its own prefix (an *errWrapper or *joinError), in which
+// case it is left untouched to avoid rendering the prefix twice.
+func (ew errWrapper) withPrefix(err error) error {
+	switch err.(type) {
+	case *errWrapper, *joinError:
+		return err
+	}
+	if ew.prefix == "" {
+		return err
+	}
+	return &errWrapper{
+		prefix:    ew.prefix,
+		err:       err,
+		errJoiner: ew.errJoiner,
+		formatter: ew.formatter,
+	}
+}
+
+// Join combines multiple errors into one, applying the wrapper's prefix to
+// each joined error that doesn't already carry its own. Nil errors are
+// dropped; if none remain, Join returns nil.
+func (ew errWrapper) Join(errs ...error) error {
+	nonNil := make([]error, 0, len(errs))
+	for _, err := range errs {
+		if err != nil {
+			nonNil = append(nonNil, ew.withPrefix(err))
+		}
+	}
+	if len(nonNil) == 0 {
+		return nil
+	}
+	return &joinError{
+		errs: nonNil,
+	}
+}
+
+// Join combines multiple errors into one with no prefix applied, mirroring
+// the standard library's errors.Join.
+func Join(errs ...error) error {
+	return New(defaultErrJoiner).(*errWrapper).Join(errs...)
+}
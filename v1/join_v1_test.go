@@ -0,0 +1,25 @@
+package errorwrapper
+
+import "testing"
+
+func TestJoinDoesNotDoublePrefix(t *testing.T) {
+	w := New('.', "A")
+	err := w.Join(w.NewErrorString("boom1"), w.NewErrorString("boom2"))
+
+	got := err.Error()
+	want := "A: boom1\nA: boom2"
+	if got != want {
+		t.Fatalf("Error() = %q, want %q", got, want)
+	}
+}
+
+func TestJoinAppliesPrefixToPlainErrors(t *testing.T) {
+	w := New('.', "A")
+	err := w.Join(&errorString{s: "boom1"}, &errorString{s: "boom2"})
+
+	got := err.Error()
+	want := "A: boom1\nA: boom2"
+	if got != want {
+		t.Fatalf("Error() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,150 @@
+package errorwrapper
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/Colduction/errorwrapper/v1/codes"
+)
+
+// errorNode is the wire representation of a single error in a wrapped tree,
+// used by MarshalJSON/UnmarshalJSON and ParseJSON to move errors across
+// process boundaries.
+type errorNode struct {
+	Prefix    string       `json:"prefix,omitempty"`
+	Msg       string       `json:"msg,omitempty"`
+	Codespace string       `json:"codespace,omitempty"`
+	Code      uint32       `json:"code,omitempty"`
+	Stack     []string     `json:"stack,omitempty"`
+	Leaf      string       `json:"leaf,omitempty"`
+	Cause     *errorNode   `json:"cause,omitempty"`
+	Causes    []*errorNode `json:"causes,omitempty"`
+}
+
+// toErrorNode converts any error into its wire representation.
+func toErrorNode(err error) *errorNode {
+	if err == nil {
+		return nil
+	}
+	switch e := err.(type) {
+	case *errWrapper:
+		node := &errorNode{
+			Prefix: e.prefix,
+			Msg:    e.msg,
+		}
+		if e.code != nil {
+			node.Codespace = e.code.Codespace
+			node.Code = e.code.Code
+		}
+		for _, frame := range e.StackTrace() {
+			node.Stack = append(node.Stack, fmt.Sprintf("%s:%d %s", frame.File, frame.Line, frame.Function))
+		}
+		node.Cause = toErrorNode(e.err)
+		return node
+	case *joinError:
+		node := &errorNode{}
+		for _, child := range e.errs {
+			node.Causes = append(node.Causes, toErrorNode(child))
+		}
+		return node
+	case *errorString:
+		return &errorNode{Leaf: e.s}
+	case *codes.CodedError:
+		return &errorNode{Leaf: e.Description, Codespace: e.Codespace, Code: e.Code}
+	default:
+		return &errorNode{Leaf: e.Error()}
+	}
+}
+
+// fromErrorNode reconstructs an error tree from its wire representation.
+// Leaves are always reconstructed as *errorString (or *codes.CodedError,
+// when codespace/code are set) so errors.Is/As keep working on the result.
+// errJoiner isn't part of the wire format (each node's prefix is already a
+// plain string), so reconstructed errWrapper nodes always use the package
+// default joiner, regardless of what the original wrapper used.
+func fromErrorNode(node *errorNode) error {
+	if node == nil {
+		return nil
+	}
+	if len(node.Causes) > 0 {
+		errs := make([]error, 0, len(node.Causes))
+		for _, c := range node.Causes {
+			errs = append(errs, fromErrorNode(c))
+		}
+		return &joinError{errs: errs}
+	}
+	isLeaf := node.Cause == nil && node.Prefix == "" && node.Msg == ""
+	if isLeaf && (node.Codespace != "" || node.Code != 0) {
+		return &codes.CodedError{Codespace: node.Codespace, Code: node.Code, Description: node.Leaf}
+	}
+	if isLeaf {
+		return &errorString{s: node.Leaf}
+	}
+	ew := &errWrapper{
+		prefix:    node.Prefix,
+		msg:       node.Msg,
+		err:       fromErrorNode(node.Cause),
+		errJoiner: defaultErrJoiner,
+	}
+	if node.Codespace != "" || node.Code != 0 {
+		ew.code = &codes.CodedError{Codespace: node.Codespace, Code: node.Code}
+	}
+	return ew
+}
+
+// MarshalJSON implements json.Marshaler, emitting a structured tree of
+// prefix/msg/cause (or causes, for joined errors), with codespace/code and
+// stack fields included when those subsystems are in use.
+func (ew errWrapper) MarshalJSON() ([]byte, error) {
+	return json.Marshal(toErrorNode(&ew))
+}
+
+// UnmarshalJSON implements json.Unmarshaler, reconstructing a wrapped error
+// tree down to its leaf so errors.Is and errors.As keep working on it.
+func (ew *errWrapper) UnmarshalJSON(data []byte) error {
+	var node errorNode
+	if err := json.Unmarshal(data, &node); err != nil {
+		return err
+	}
+	reconstructed, ok := fromErrorNode(&node).(*errWrapper)
+	if !ok {
+		reconstructed = &errWrapper{prefix: node.Prefix, msg: node.Msg}
+	}
+	*ew = *reconstructed
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler for joinError, emitting
+// {"causes":[...]}. Join and the package-level Join both return a bare
+// *joinError, so this is required for json.Marshal to see anything more
+// than "{}" when called directly on a joined error.
+func (je *joinError) MarshalJSON() ([]byte, error) {
+	return json.Marshal(toErrorNode(je))
+}
+
+// UnmarshalJSON implements json.Unmarshaler for joinError, reconstructing
+// each joined error down to its leaf so errors.Is and errors.As keep
+// working on the result.
+func (je *joinError) UnmarshalJSON(data []byte) error {
+	var node errorNode
+	if err := json.Unmarshal(data, &node); err != nil {
+		return err
+	}
+	reconstructed, ok := fromErrorNode(&node).(*joinError)
+	if !ok {
+		reconstructed = &joinError{}
+	}
+	*je = *reconstructed
+	return nil
+}
+
+// ParseJSON reconstructs a wrapped error tree previously produced by
+// MarshalJSON. It returns the reconstructed error and, separately, any error
+// encountered while parsing data itself.
+func ParseJSON(data []byte) (error, error) {
+	var node errorNode
+	if err := json.Unmarshal(data, &node); err != nil {
+		return nil, err
+	}
+	return fromErrorNode(&node), nil
+}
@@ -0,0 +1,61 @@
+package errorwrapper
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestJoinErrorMarshalJSON(t *testing.T) {
+	w := New('.', "A")
+	joined := w.Join(w.NewErrorString("boom1"), w.NewErrorString("boom2"))
+
+	data, err := json.Marshal(joined)
+	if err != nil {
+		t.Fatalf("json.Marshal: %v", err)
+	}
+	if string(data) == "{}" {
+		t.Fatalf("json.Marshal(joinError) produced %q, want a populated tree", data)
+	}
+
+	parsed, parseErr := ParseJSON(data)
+	if parseErr != nil {
+		t.Fatalf("ParseJSON: %v", parseErr)
+	}
+	je, ok := parsed.(*joinError)
+	if !ok {
+		t.Fatalf("ParseJSON returned %T, want *joinError", parsed)
+	}
+	if len(je.errs) != 2 {
+		t.Fatalf("reconstructed joinError has %d children, want 2", len(je.errs))
+	}
+	if got, want := je.Error(), joined.Error(); got != want {
+		t.Fatalf("reconstructed joinError.Error() = %q, want %q", got, want)
+	}
+}
+
+func TestErrWrapperMarshalJSONRoundTrip(t *testing.T) {
+	w1 := New('.', "LEVEL1")
+	w2 := New('.', "LEVEL2")
+	e1 := w1.NewErrorString("boom")
+	original := w2.NewError(e1, "m2")
+
+	data, err := json.Marshal(original)
+	if err != nil {
+		t.Fatalf("json.Marshal: %v", err)
+	}
+	if string(data) == "{}" {
+		t.Fatalf("json.Marshal(errWrapper) produced %q, want a populated tree", data)
+	}
+
+	parsed, parseErr := ParseJSON(data)
+	if parseErr != nil {
+		t.Fatalf("ParseJSON: %v", parseErr)
+	}
+	ew, ok := parsed.(*errWrapper)
+	if !ok {
+		t.Fatalf("ParseJSON returned %T, want *errWrapper", parsed)
+	}
+	if got, want := ew.Error(), original.Error(); got != want {
+		t.Fatalf("reconstructed errWrapper.Error() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,32 @@
+package errorwrapper
+
+import "strings"
+
+// sentinelError is a prefix-tagged error created by Register. It exists
+// purely as a comparable target for errors.Is; it is never itself wrapped.
+type sentinelError struct {
+	prefix string
+	msg    string
+}
+
+// Error implements the error interface for sentinelError.
+func (s *sentinelError) Error() string {
+	var sb strings.Builder
+	if s.prefix != "" {
+		sb.WriteString(s.prefix)
+		sb.WriteString(defaultMsgJoiner)
+	}
+	sb.WriteString(s.msg)
+	return sb.String()
+}
+
+// Register creates a sentinel error tagged with prefix and msg. Use it to
+// declare package-level sentinels (e.g. var ErrNotFound = errorwrapper.Register("api.db", "not found"))
+// that errors.Is can match against any errWrapper in a chain sharing the
+// same prefix and message, via errWrapper's Is method.
+func Register(prefix string, msg string) error {
+	return &sentinelError{
+		prefix: prefix,
+		msg:    msg,
+	}
+}
@@ -0,0 +1,38 @@
+package errorwrapper
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSentinelMatchesAcrossWrapLevels(t *testing.T) {
+	errNotFound := Register("svc.repo", "not found")
+	w := New('.', "svc.repo")
+
+	inner := w.NewErrorString("boom", "not found")
+	err := New('.', "api").NewError(inner, "lookup failed")
+	if !errors.Is(err, errNotFound) {
+		t.Fatalf("errors.Is(err, errNotFound) = false, want true")
+	}
+
+	other := Register("svc.repo", "forbidden")
+	if errors.Is(err, other) {
+		t.Fatalf("errors.Is(err, other) = true, want false (msg differs)")
+	}
+}
+
+func TestMultiLevelWrapDoesNotAccumulatePrefix(t *testing.T) {
+	w1 := New('.', "LEVEL1")
+	w2 := New('.', "LEVEL2")
+	w3 := New('.', "LEVEL3")
+
+	e1 := w1.NewErrorString("boom")
+	e2 := w2.NewError(e1, "m2")
+	e3 := w3.NewError(e2, "m3")
+
+	got := e3.Error()
+	want := "LEVEL3.LEVEL2.LEVEL1: [m3] boom"
+	if got != want {
+		t.Fatalf("Error() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,57 @@
+package errorwrapper
+
+import (
+	"fmt"
+	"io"
+	"runtime"
+)
+
+// maxStackDepth bounds how many call frames captureStack records.
+const maxStackDepth = 32
+
+// captureStack records the call stack of its caller's caller, skipping the
+// runtime.Callers and captureStack frames themselves.
+func captureStack() []uintptr {
+	pcs := make([]uintptr, maxStackDepth)
+	n := runtime.Callers(3, pcs)
+	return pcs[:n]
+}
+
+// StackTrace expands the captured call stack into runtime.Frame values.
+// It returns nil if the errWrapper's ErrorWrapper was not built with
+// WithStackTrace. Expansion is done lazily, on demand, rather than at
+// capture time.
+func (ew *errWrapper) StackTrace() []runtime.Frame {
+	if len(ew.pcs) == 0 {
+		return nil
+	}
+	frames := runtime.CallersFrames(ew.pcs)
+	out := make([]runtime.Frame, 0, len(ew.pcs))
+	for {
+		frame, more := frames.Next()
+		out = append(out, frame)
+		if !more {
+			break
+		}
+	}
+	return out
+}
+
+// Format implements fmt.Formatter. "%s" and "%v" print the same compact
+// output as Error(); "%+v" additionally appends a "file:line function" line
+// for each captured stack frame.
+func (ew *errWrapper) Format(s fmt.State, verb rune) {
+	switch verb {
+	case 'v':
+		io.WriteString(s, ew.Error())
+		if s.Flag('+') {
+			for _, frame := range ew.StackTrace() {
+				fmt.Fprintf(s, "\n%s:%d %s", frame.File, frame.Line, frame.Function)
+			}
+		}
+	case 's':
+		io.WriteString(s, ew.Error())
+	default:
+		fmt.Fprintf(s, "%%!%c(errorwrapper.errWrapper=%s)", verb, ew.Error())
+	}
+}
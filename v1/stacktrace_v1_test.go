@@ -0,0 +1,38 @@
+package errorwrapper
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestStackTraceCapturedOnlyWithWithStackTrace(t *testing.T) {
+	w := New('.', "A")
+	err := w.NewErrorString("boom").(*errWrapper)
+	if frames := err.StackTrace(); len(frames) != 0 {
+		t.Fatalf("StackTrace() = %d frames, want 0 (WithStackTrace not used)", len(frames))
+	}
+
+	stacked := w.WithStackTrace()
+	stackedErr := stacked.NewErrorString("boom").(*errWrapper)
+	if frames := stackedErr.StackTrace(); len(frames) == 0 {
+		t.Fatalf("StackTrace() is empty, want frames from WithStackTrace")
+	}
+}
+
+func TestFormatPlusVAppendsStackFrames(t *testing.T) {
+	err := New('.', "A").WithStackTrace().NewErrorString("boom").(*errWrapper)
+
+	plain := fmt.Sprintf("%v", err)
+	if plain != err.Error() {
+		t.Fatalf("%%v = %q, want %q", plain, err.Error())
+	}
+
+	verbose := fmt.Sprintf("%+v", err)
+	if !strings.HasPrefix(verbose, err.Error()) {
+		t.Fatalf("%%+v = %q, want it to start with %q", verbose, err.Error())
+	}
+	if got, want := strings.Count(verbose, "\n"), len(err.StackTrace()); got != want {
+		t.Fatalf("%%+v has %d frame lines, want %d (one per captured frame)", got, want)
+	}
+}